@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BackupStorageType is the kind of object storage a PXCBackupSpec can be
+// written to.
+type BackupStorageType string
+
+const (
+	BackupStorageFilesystem BackupStorageType = "filesystem"
+	BackupStorageS3         BackupStorageType = "s3"
+	BackupStorageGCS        BackupStorageType = "gcs"
+	BackupStorageAzure      BackupStorageType = "azure"
+)
+
+// BackupStorageSpec configures one named storage destination referenced by
+// PXCBackupSpec.StorageName. Only the fields matching Type are read.
+type BackupStorageSpec struct {
+	Type BackupStorageType `json:"type"`
+
+	Volume *BackupStorageVolumeSpec `json:"volume,omitempty"`
+	S3     BackupStorageS3Spec      `json:"s3,omitempty"`
+	GCS    BackupStorageGCSSpec     `json:"gcs,omitempty"`
+	Azure  BackupStorageAzureSpec   `json:"azure,omitempty"`
+}
+
+// BackupStorageVolumeSpec wraps the PVC spec used for BackupStorageFilesystem.
+type BackupStorageVolumeSpec struct {
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+}
+
+// BackupStorageS3Spec configures an S3-compatible storage destination.
+type BackupStorageS3Spec struct {
+	Bucket            string `json:"bucket"`
+	CredentialsSecret string `json:"credentialsSecret"`
+	Region            string `json:"region,omitempty"`
+	EndpointURL       string `json:"endpointUrl,omitempty"`
+}
+
+// BackupStorageGCSSpec configures a Google Cloud Storage destination. The
+// credentials secret is expected to hold a service-account JSON key under
+// the "credentials.json" key, matching rclone/gsutil conventions.
+type BackupStorageGCSSpec struct {
+	Bucket            string `json:"bucket"`
+	CredentialsSecret string `json:"credentialsSecret"`
+	EndpointURL       string `json:"endpointUrl,omitempty"`
+}
+
+// BackupStorageAzureSpec configures an Azure Blob Storage destination. The
+// credentials secret is expected to hold "AZURE_STORAGE_ACCOUNT_NAME" and
+// "AZURE_STORAGE_ACCOUNT_KEY" keys.
+type BackupStorageAzureSpec struct {
+	Container         string `json:"container"`
+	CredentialsSecret string `json:"credentialsSecret"`
+	EndpointURL       string `json:"endpointUrl,omitempty"`
+}
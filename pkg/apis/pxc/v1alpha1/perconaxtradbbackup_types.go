@@ -0,0 +1,130 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PXCBackupSpec defines the desired state of PerconaXtraDBBackup
+type PXCBackupSpec struct {
+	PXCCluster  string `json:"pxcCluster"`
+	StorageName string `json:"storageName,omitempty"`
+
+	// Encryption, if set, wraps the xtrabackup stream with xbstream
+	// --encrypt before it reaches the storage destination.
+	Encryption *BackupEncryptionSpec `json:"encryption,omitempty"`
+}
+
+// BackupEncryptionAlgorithm is an xbstream --encrypt cipher name.
+type BackupEncryptionAlgorithm string
+
+const (
+	BackupEncryptionAES256 BackupEncryptionAlgorithm = "AES256"
+)
+
+// BackupEncryptionSpec configures at-rest encryption of the backup stream.
+// The key is read from KeySecret[KeySecretKey]; KMSKeyID is passed through
+// to rclone/xbcloud untouched for providers that manage the key themselves
+// (e.g. SSE-KMS) instead of a symmetric key from a Secret.
+type BackupEncryptionSpec struct {
+	Algorithm BackupEncryptionAlgorithm `json:"algorithm,omitempty"`
+
+	KeySecret    string `json:"keySecret,omitempty"`
+	KeySecretKey string `json:"keySecretKey,omitempty"`
+
+	KMSKeyID string `json:"kmsKeyId,omitempty"`
+}
+
+// BackupState describes the state of the underlying backup Job, mirrored
+// from the Job's Active/Succeeded/Failed counters.
+type BackupState string
+
+const (
+	BackupStarting  BackupState = "Starting"
+	BackupRunning   BackupState = "Running"
+	BackupSucceeded BackupState = "Succeeded"
+	BackupFailed    BackupState = "Failed"
+)
+
+// BackupPhase describes where in the reconcile state machine a backup is.
+// Unlike State, which reflects the child Job, Phase is owned and advanced
+// solely by the controller.
+type BackupPhase string
+
+const (
+	BackupPhaseNew             BackupPhase = "New"
+	BackupPhasePVCProvisioning BackupPhase = "PVCProvisioning"
+	BackupPhaseJobCreated      BackupPhase = "JobCreated"
+	BackupPhaseRunning         BackupPhase = "Running"
+	BackupPhaseSucceeded       BackupPhase = "Succeeded"
+	BackupPhaseFailed          BackupPhase = "Failed"
+)
+
+// PXCBackupStatus defines the observed state of PerconaXtraDBBackup
+type PXCBackupStatus struct {
+	State       BackupState             `json:"state,omitempty"`
+	Phase       BackupPhase             `json:"phase,omitempty"`
+	Destination string                  `json:"destination,omitempty"`
+	StorageName string                  `json:"storageName,omitempty"`
+	S3          *BackupStorageS3Spec    `json:"s3,omitempty"`
+	GCS         *BackupStorageGCSSpec   `json:"gcs,omitempty"`
+	Azure       *BackupStorageAzureSpec `json:"azure,omitempty"`
+	CompletedAt *metav1.Time            `json:"completedAt,omitempty"`
+
+	// Encryption mirrors Spec.Encryption so restore can tell, without
+	// reading the cluster's current PXCBackupSpec, how this particular
+	// backup was encrypted.
+	Encryption *BackupEncryptionSpec `json:"encryption,omitempty"`
+
+	// Conditions records the history of phase transitions this backup has
+	// gone through, in the standard k8s condition shape.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBBackup is the Schema for the perconaxtradbbackups API
+type PerconaXtraDBBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PXCBackupSpec   `json:"spec,omitempty"`
+	Status PXCBackupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBBackupList contains a list of PerconaXtraDBBackup
+type PerconaXtraDBBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBBackup `json:"items"`
+}
+
+// OwnerRef returns OwnerReference to this PerconaXtraDBBackup object
+func (cr *PerconaXtraDBBackup) OwnerRef(scheme *runtime.Scheme) (metav1.OwnerReference, error) {
+	gvks, _, err := scheme.ObjectKinds(cr)
+	if err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	if len(gvks) == 0 {
+		return metav1.OwnerReference{}, fmt.Errorf("no ObjectKinds registered for PerconaXtraDBBackup")
+	}
+	gvk := gvks[0]
+
+	trueVar := true
+
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       cr.GetName(),
+		UID:        cr.GetUID(),
+		Controller: &trueVar,
+	}, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&PerconaXtraDBBackup{}, &PerconaXtraDBBackupList{})
+}
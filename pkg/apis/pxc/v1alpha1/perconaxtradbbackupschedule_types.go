@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PXCBackupScheduleSpec defines the desired state of PerconaXtraDBBackupSchedule
+type PXCBackupScheduleSpec struct {
+	// Schedule is a standard cron string (e.g. "0 0 * * *").
+	Schedule    string `json:"schedule"`
+	StorageName string `json:"storageName,omitempty"`
+	PXCCluster  string `json:"pxcCluster"`
+
+	// KeepLast is the number of most recent succeeded backups to retain
+	// regardless of the GFS counters below.
+	KeepLast int `json:"keepLast,omitempty"`
+
+	// KeepDaily/KeepWeekly/KeepMonthly implement GFS-style retention: at
+	// most one succeeded backup is kept per day/week/month for this many
+	// days/weeks/months, on top of KeepLast.
+	KeepDaily   *int `json:"keepDaily,omitempty"`
+	KeepWeekly  *int `json:"keepWeekly,omitempty"`
+	KeepMonthly *int `json:"keepMonthly,omitempty"`
+}
+
+// PXCBackupScheduleStatus defines the observed state of PerconaXtraDBBackupSchedule
+type PXCBackupScheduleStatus struct {
+	LastScheduleTime   *metav1.Time `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// ActiveBackups lists, newest first, the names of the succeeded
+	// PerconaXtraDBBackup objects of this schedule that the last retention
+	// pass kept. Backups that have not succeeded are not listed.
+	ActiveBackups []string `json:"activeBackups,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBBackupSchedule is the Schema for the perconaxtradbbackupschedules API
+type PerconaXtraDBBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PXCBackupScheduleSpec   `json:"spec,omitempty"`
+	Status PXCBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBBackupScheduleList contains a list of PerconaXtraDBBackupSchedule
+type PerconaXtraDBBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBBackupSchedule `json:"items"`
+}
+
+// OwnerRef returns OwnerReference to this PerconaXtraDBBackupSchedule object
+func (cr *PerconaXtraDBBackupSchedule) OwnerRef(scheme *runtime.Scheme) (metav1.OwnerReference, error) {
+	gvks, _, err := scheme.ObjectKinds(cr)
+	if err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	if len(gvks) == 0 {
+		return metav1.OwnerReference{}, fmt.Errorf("no ObjectKinds registered for PerconaXtraDBBackupSchedule")
+	}
+	gvk := gvks[0]
+
+	trueVar := true
+
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       cr.GetName(),
+		UID:        cr.GetUID(),
+		Controller: &trueVar,
+	}, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&PerconaXtraDBBackupSchedule{}, &PerconaXtraDBBackupScheduleList{})
+}
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// PerconaXtraDBRestoreSpec defines the desired state of PerconaXtraDBRestore
+type PerconaXtraDBRestoreSpec struct {
+	PXCCluster string `json:"pxcCluster"`
+	BackupName string `json:"backupName"`
+}
+
+// PerconaXtraDBRestoreStatus defines the observed state of PerconaXtraDBRestore
+type PerconaXtraDBRestoreStatus struct {
+	State       RestoreState `json:"state,omitempty"`
+	Comments    string       `json:"comments,omitempty"`
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+
+	// PausedReplicas is the PXC StatefulSet's replica count before the
+	// restore paused the cluster to block SST/writes to the data PVCs
+	// being restored into. It is restored once the restore Job finishes.
+	PausedReplicas *int32 `json:"pausedReplicas,omitempty"`
+}
+
+// RestoreState describes the phase of a restore job
+type RestoreState string
+
+const (
+	RestoreStarting  RestoreState = "Starting"
+	RestoreRunning   RestoreState = "Running"
+	RestoreSucceeded RestoreState = "Succeeded"
+	RestoreFailed    RestoreState = "Failed"
+
+	// RestorePausingCluster is set while the target PXC StatefulSet is
+	// being scaled to 0 so nothing writes to its data PVCs while the
+	// restore Job repopulates them.
+	RestorePausingCluster RestoreState = "PausingCluster"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBRestore is the Schema for the perconaxtradbrestores API
+type PerconaXtraDBRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBRestoreSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBRestoreStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBRestoreList contains a list of PerconaXtraDBRestore
+type PerconaXtraDBRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBRestore `json:"items"`
+}
+
+// OwnerRef returns OwnerReference to this PerconaXtraDBRestore object
+func (cr *PerconaXtraDBRestore) OwnerRef(scheme *runtime.Scheme) (metav1.OwnerReference, error) {
+	gvks, _, err := scheme.ObjectKinds(cr)
+	if err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	if len(gvks) == 0 {
+		return metav1.OwnerReference{}, fmt.Errorf("no ObjectKinds registered for PerconaXtraDBRestore")
+	}
+	gvk := gvks[0]
+
+	trueVar := true
+
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       cr.GetName(),
+		UID:        cr.GetUID(),
+		Controller: &trueVar,
+	}, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&PerconaXtraDBRestore{}, &PerconaXtraDBRestoreList{})
+}
@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/controller/perconaxtradbbackup/adopt"
+)
+
+func init() {
+	AddToManagerFuncs = append(AddToManagerFuncs, adopt.Add)
+}
@@ -0,0 +1,9 @@
+package controller
+
+import (
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/controller/perconaxtradbrestore"
+)
+
+func init() {
+	AddToManagerFuncs = append(AddToManagerFuncs, perconaxtradbrestore.Add)
+}
@@ -0,0 +1,269 @@
+// Package adopt regenerates PerconaXtraDBBackup objects for backup
+// artifacts (S3 objects, backup PVCs) that have outlived the Kubernetes CR
+// that originally created them - for example after a disaster-recovery
+// failover rebuilds the cluster in a new namespace or cluster, where the
+// underlying storage survives but the CRs describing it do not.
+package adopt
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+)
+
+var log = logf.Log.WithName("controller_perconaxtradbbackup_adopt")
+
+// resyncInterval is how often the adopter re-scans storage for artifacts
+// that don't have a matching PerconaXtraDBBackup CR yet.
+const resyncInterval = time.Hour
+
+// adoptedLabel marks a PerconaXtraDBBackup created by this controller,
+// distinguishing it from ones created by the normal backup/schedule flow.
+const adoptedLabel = "pxc-backup-adopted"
+
+// objectNameRE matches the "<cluster>-<timestamp>-xtrabackup.stream" naming
+// convention that ReconcilePerconaXtraDBBackup generates for S3 (and GCS/
+// Azure) destinations.
+var objectNameRE = regexp.MustCompile(`^(.+)-(\d{4}-\d{2}-\d{2}-\d{2}:\d{2}:\d{2})-xtrabackup\.stream$`)
+
+// legacyPVCNameRE matches the "<cluster>-xb-cron-pvc" naming convention used
+// before backup PVCs were given unique, per-backup names.
+//
+// This is legacy-only: storage.PVCName now derives "<backup-name>-xb-pvc",
+// and a backup's name (e.g. "<schedule-name>-<timestamp>" for scheduled
+// backups) doesn't deterministically encode the owning cluster's name the
+// way the old fixed name did, so there's no equivalent pattern to add here.
+// Current-generation filesystem PVCs orphaned after their CR is gone are not
+// recognized by resyncFilesystem; this path only backfills PVCs left over
+// from clusters that predate per-backup PVC names.
+var legacyPVCNameRE = regexp.MustCompile(`^(.+)-xb-cron-pvc$`)
+
+// Add registers the adopter with mgr as a Runnable, so it starts and stops
+// together with the rest of the manager instead of being wired to any
+// particular CR's watch.
+func Add(mgr manager.Manager) error {
+	return mgr.Add(&Adopter{
+		client: mgr.GetClient(),
+	})
+}
+
+// Adopter implements manager.Runnable.
+type Adopter struct {
+	client client.Client
+}
+
+// Start runs an initial resync immediately, then one every resyncInterval
+// until stop is closed.
+func (a *Adopter) Start(stop <-chan struct{}) error {
+	a.resync()
+
+	ticker := time.NewTicker(resyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			a.resync()
+		}
+	}
+}
+
+func (a *Adopter) resync() {
+	clusters := api.PerconaXtraDBClusterList{}
+	if err := a.client.List(context.TODO(), &client.ListOptions{}, &clusters); err != nil {
+		log.Error(err, "list clusters")
+		return
+	}
+
+	for _, cluster := range clusters.Items {
+		if err := a.resyncCluster(&cluster); err != nil {
+			log.Error(err, "resync cluster", "Namespace", cluster.Namespace, "Name", cluster.Name)
+		}
+	}
+}
+
+func (a *Adopter) resyncCluster(cluster *api.PerconaXtraDBCluster) error {
+	if cluster.Spec.Backup == nil {
+		return nil
+	}
+
+	existing, err := a.existingDestinations(cluster.Namespace)
+	if err != nil {
+		return fmt.Errorf("list existing backups: %v", err)
+	}
+
+	for storageName, storage := range cluster.Spec.Backup.Storages {
+		switch storage.Type {
+		case api.BackupStorageS3:
+			if err := a.resyncS3(cluster, storageName, storage.S3, existing); err != nil {
+				return fmt.Errorf("resync s3 storage %s: %v", storageName, err)
+			}
+		case api.BackupStorageFilesystem:
+			if err := a.resyncFilesystem(cluster, storageName, existing); err != nil {
+				return fmt.Errorf("resync filesystem storage %s: %v", storageName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// existingDestinations returns the Status.Destination of every
+// PerconaXtraDBBackup already present in namespace, so resync can skip
+// artifacts that already have a CR.
+func (a *Adopter) existingDestinations(namespace string) (map[string]bool, error) {
+	backups := api.PerconaXtraDBBackupList{}
+	err := a.client.List(context.TODO(), &client.ListOptions{Namespace: namespace}, &backups)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations := make(map[string]bool, len(backups.Items))
+	for _, bcp := range backups.Items {
+		destinations[bcp.Status.Destination] = true
+	}
+
+	return destinations, nil
+}
+
+func (a *Adopter) resyncS3(cluster *api.PerconaXtraDBCluster, storageName string, spec api.BackupStorageS3Spec, existing map[string]bool) error {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: spec.CredentialsSecret, Namespace: cluster.Namespace}
+	if err := a.client.Get(context.TODO(), key, secret); err != nil {
+		return fmt.Errorf("get s3 credentials secret: %v", err)
+	}
+
+	objects, err := backup.ListObjects(spec, secret, cluster.Name+"-")
+	if err != nil {
+		return fmt.Errorf("list objects: %v", err)
+	}
+
+	for _, obj := range objects {
+		if !objectNameRE.MatchString(obj.Key) {
+			continue
+		}
+
+		destination := "s3://" + strings.TrimPrefix(spec.Bucket, "s3://") + "/" + obj.Key
+		if existing[destination] {
+			continue
+		}
+
+		bcp := a.syntheticBackup(cluster, storageName, destination, obj.LastModified)
+		bcp.Status.S3 = &spec
+
+		if err := a.createAdopted(bcp); err != nil {
+			return fmt.Errorf("adopt %s: %v", destination, err)
+		}
+	}
+
+	return nil
+}
+
+// resyncFilesystem only adopts PVCs matching legacyPVCNameRE - see its doc
+// comment for why current-generation per-backup PVCs aren't covered.
+func (a *Adopter) resyncFilesystem(cluster *api.PerconaXtraDBCluster, storageName string, existing map[string]bool) error {
+	pvcs := corev1.PersistentVolumeClaimList{}
+	err := a.client.List(context.TODO(),
+		&client.ListOptions{
+			Namespace:     cluster.Namespace,
+			LabelSelector: labels.Everything(),
+		},
+		&pvcs,
+	)
+	if err != nil {
+		return fmt.Errorf("list pvcs: %v", err)
+	}
+
+	for _, pvc := range pvcs.Items {
+		m := legacyPVCNameRE.FindStringSubmatch(pvc.Name)
+		if m == nil || m[1] != cluster.Name {
+			continue
+		}
+
+		destination := "pvc/" + pvc.Name
+		if existing[destination] {
+			continue
+		}
+
+		bcp := a.syntheticBackup(cluster, storageName, destination, pvc.CreationTimestamp.Time)
+
+		if err := a.createAdopted(bcp); err != nil {
+			return fmt.Errorf("adopt %s: %v", destination, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *Adopter) syntheticBackup(cluster *api.PerconaXtraDBCluster, storageName, destination string, completedAt time.Time) *api.PerconaXtraDBBackup {
+	completed := metav1.NewTime(completedAt)
+
+	return &api.PerconaXtraDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      adoptedName(cluster.Name, destination),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				adoptedLabel: "true",
+			},
+		},
+		Spec: api.PXCBackupSpec{
+			PXCCluster:  cluster.Name,
+			StorageName: storageName,
+		},
+		Status: api.PXCBackupStatus{
+			State:       api.BackupSucceeded,
+			Phase:       api.BackupPhaseSucceeded,
+			Destination: destination,
+			StorageName: storageName,
+			CompletedAt: &completed,
+		},
+	}
+}
+
+func (a *Adopter) createAdopted(bcp *api.PerconaXtraDBBackup) error {
+	err := a.client.Create(context.TODO(), bcp)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Adopted orphaned backup artifact", "Namespace", bcp.Namespace, "Name", bcp.Name, "Destination", bcp.Status.Destination)
+	return nil
+}
+
+// adoptedName derives a stable, DNS-safe CR name from the cluster and
+// destination so re-running resync never creates duplicates for the same
+// artifact.
+func adoptedName(clusterName, destination string) string {
+	h := sanitize(destination)
+	return fmt.Sprintf("adopted-%s-%s", clusterName, h)
+}
+
+func sanitize(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			out = append(out, r)
+		case r >= 'A' && r <= 'Z':
+			out = append(out, r+('a'-'A'))
+		default:
+			out = append(out, '-')
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,38 @@
+package adopt
+
+import "testing"
+
+func TestSanitize(t *testing.T) {
+	cases := map[string]string{
+		"pvc/cluster1-xb-cron-pvc":                     "pvc-cluster1-xb-cron-pvc",
+		"s3://my-bucket/cluster1-2021-01-02-03:04:05-xtrabackup.stream": "s3---my-bucket-cluster1-2021-01-02-03-04-05-xtrabackup-stream",
+		"UPPER":  "upper",
+		"MiXeD1": "mixed1",
+	}
+
+	for in, want := range cases {
+		if got := sanitize(in); got != want {
+			t.Errorf("sanitize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestAdoptedName(t *testing.T) {
+	name := adoptedName("cluster1", "pvc/cluster1-xb-cron-pvc")
+	want := "adopted-cluster1-pvc-cluster1-xb-cron-pvc"
+	if name != want {
+		t.Errorf("adoptedName() = %q, want %q", name, want)
+	}
+
+	// Same cluster and destination must always derive the same name, so
+	// resync never creates duplicate CRs for the same artifact.
+	if again := adoptedName("cluster1", "pvc/cluster1-xb-cron-pvc"); again != name {
+		t.Errorf("adoptedName() is not stable: %q != %q", again, name)
+	}
+
+	// Different destinations must not collide.
+	other := adoptedName("cluster1", "pvc/cluster1-xb-cron-pvc-2")
+	if other == name {
+		t.Errorf("adoptedName() collided for different destinations: %q", name)
+	}
+}
@@ -2,13 +2,11 @@ package perconaxtradbbackup
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
-	"reflect"
-	"strings"
 	"time"
 
-	batchv1 "k8s.io/api/batch/v1"
-	corev1 "k8s.io/api/core/v1"
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -23,6 +21,7 @@ import (
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
@@ -82,18 +81,27 @@ type ReconcilePerconaXtraDBBackup struct {
 	serverVersion *api.ServerVersion
 }
 
+// requeueFast is used while a phase is waiting on something short-lived
+// (PVC binding, Job admission). requeueSlow is used while the Job is simply
+// running and we're waiting on its status to change.
+const (
+	requeueFast = time.Second * 5
+	requeueSlow = time.Second * 15
+)
+
 // Reconcile reads that state of the cluster for a PerconaXtraDBBackup object and makes changes based on the state read
 // and what is in the PerconaXtraDBBackup.Spec
+//
+// Reconcile is driven by instance.Status.Phase: each phase is handled by one
+// small function that either advances the phase (and is requeued to pick up
+// the next one) or returns a phase-appropriate requeue interval. Terminal
+// phases (Succeeded, Failed) short-circuit before touching any child object.
+//
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcilePerconaXtraDBBackup) Reconcile(request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
-	// reqLogger.Info("Reconciling PerconaXtraDBBackup")
-
-	rr := reconcile.Result{
-		RequeueAfter: time.Second * 5,
-	}
 
 	// Fetch the PerconaXtraDBBackup instance
 	instance := &api.PerconaXtraDBBackup{}
@@ -103,97 +111,138 @@ func (r *ReconcilePerconaXtraDBBackup) Reconcile(request reconcile.Request) (rec
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
-			return rr, nil
+			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	cluster, err := r.getClusterConfig(instance)
+	switch instance.Status.Phase {
+	case api.BackupPhaseSucceeded, api.BackupPhaseFailed:
+		// terminal: nothing left to do, never recreate the Job/PVC.
+		return reconcile.Result{}, nil
+	case "", api.BackupPhaseNew:
+		return r.reconcileNew(reqLogger, instance)
+	case api.BackupPhasePVCProvisioning:
+		return r.reconcilePVCProvisioning(reqLogger, instance)
+	case api.BackupPhaseJobCreated:
+		return r.reconcileJobCreated(reqLogger, instance)
+	case api.BackupPhaseRunning:
+		return r.reconcileRunning(reqLogger, instance)
+	}
+
+	return reconcile.Result{}, fmt.Errorf("unknown backup phase: %q", instance.Status.Phase)
+}
+
+// reconcileNew validates the target cluster/storage and decides whether a
+// PVC needs to be provisioned before the Job can be created.
+func (r *ReconcilePerconaXtraDBBackup) reconcileNew(reqLogger logr.Logger, instance *api.PerconaXtraDBBackup) (reconcile.Result, error) {
+	_, bcpStorage, err := r.validate(instance)
 	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("invalid backup cluster: %v", err)
+		return reconcile.Result{}, err
 	}
 
-	if cluster.Spec.Backup == nil {
-		return reconcile.Result{}, fmt.Errorf("a backup image should be set in the PXC config")
+	next := api.BackupPhaseJobCreated
+	if bcpStorage.Type == api.BackupStorageFilesystem {
+		next = api.BackupPhasePVCProvisioning
 	}
 
-	bcp := backup.New(cluster, cluster.Spec.Backup)
-	job := bcp.Job(instance)
+	return reconcile.Result{RequeueAfter: requeueFast}, r.transitionPhase(instance, next, "ValidationSucceeded", "backup cluster and storage validated")
+}
+
+// reconcilePVCProvisioning creates (if needed) the backup PVC and checks its
+// bind status once per reconcile, advancing to JobCreated once it's Bound.
+// It never blocks inside this call waiting for the PVC: a still-Pending PVC
+// just requeues for another look, the same as any other in-progress phase.
+func (r *ReconcilePerconaXtraDBBackup) reconcilePVCProvisioning(reqLogger logr.Logger, instance *api.PerconaXtraDBBackup) (reconcile.Result, error) {
+	_, bcpStorage, err := r.validate(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	reconciler := storage.NewPVCStorageReconciler(r.client, r.scheme, bcpStorage.Volume)
+
+	destination, _, err := reconciler.Ensure(context.TODO(), instance)
+	if err != nil {
+		var unavailableErr *storage.PVCUnavailableError
+		if stderrors.As(err, &unavailableErr) {
+			setCondition(instance, "PVCUnavailable", metav1.ConditionTrue, "PVC"+unavailableErr.Status, unavailableErr.Error())
+			return reconcile.Result{}, r.fail(instance, "PVCUnavailable", unavailableErr.Error())
+		}
+
+		var pendingErr *storage.PVCPendingError
+		if stderrors.As(err, &pendingErr) {
+			return reconcile.Result{RequeueAfter: requeueFast}, r.setCondition(instance, "PVCUnavailable", metav1.ConditionFalse, "PVCPending", pendingErr.Error())
+		}
+
+		return reconcile.Result{}, fmt.Errorf("ensure backup pvc: %v", err)
+	}
+
+	instance.Status.Destination = destination
+
+	return reconcile.Result{RequeueAfter: requeueFast}, r.transitionPhase(instance, api.BackupPhaseJobCreated, "PVCBound", "backup storage "+destination+" is ready")
+}
+
+// reconcileJobCreated builds the backup Job (pointed at the right storage)
+// and creates it, tolerating AlreadyExists since this phase can be retried.
+func (r *ReconcilePerconaXtraDBBackup) reconcileJobCreated(reqLogger logr.Logger, instance *api.PerconaXtraDBBackup) (reconcile.Result, error) {
+	cluster, bcpStorage, err := r.validate(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
 	bcpNode, err := r.SelectNode(instance)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("select backup node: %v", err)
 	}
 
-	bcpStorage, ok := cluster.Spec.Backup.Storages[instance.Spec.StorageName]
-	if !ok {
-		return reconcile.Result{}, fmt.Errorf("bcpStorage %s doesn't exist", instance.Spec.StorageName)
-	}
+	bcp := backup.New(cluster, cluster.Spec.Backup)
+	job := bcp.Job(instance)
+	job.Spec = bcp.JobSpec(instance.Spec, bcpNode, r.serverVersion)
 
 	var destination string
 	var s3status *api.BackupStorageS3Spec
+	var gcsStatus *api.BackupStorageGCSSpec
+	var azureStatus *api.BackupStorageAzureSpec
 
-	job.Spec = bcp.JobSpec(instance.Spec, bcpNode, r.serverVersion)
 	switch bcpStorage.Type {
 	case api.BackupStorageFilesystem:
-		pvc := backup.NewPVC(instance)
-		pvc.Spec = *bcpStorage.Volume.PersistentVolumeClaim
-		pvc.ObjectMeta.Name = "cluster1-xb-cron-pvc"
+		pvcName := storage.PVCName(instance)
+		destination = "pvc/" + pvcName
 
-		destination = "pvc/" + pvc.Name
-
-		// Set PerconaXtraDBBackup instance as the owner and controller
-		if err := setControllerReference(instance, pvc, r.scheme); err != nil {
-			return reconcile.Result{}, fmt.Errorf("setControllerReference: %v", err)
+		if err := bcp.SetStoragePVC(&job.Spec, pvcName); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage FS: %v", err)
 		}
-
-		// Check if this PVC already exists
-		err = r.client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, pvc)
-		if err != nil && errors.IsNotFound(err) {
-			reqLogger.Info("Creating a new volume for backup", "Namespace", pvc.Namespace, "Name", pvc.Name)
-			err = r.client.Create(context.TODO(), pvc)
-			if err != nil {
-				return reconcile.Result{}, fmt.Errorf("create backup pvc: %v", err)
-			}
+	case api.BackupStorageS3:
+		destination, _, err = storage.NewS3StorageReconciler(bcpStorage.S3).Ensure(context.TODO(), instance)
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("ensure S3 destination: %v", err)
 		}
-
-		// getting the volume status
-		var pvcStatus VolumeStatus
-		for i := time.Duration(1); i <= 5; i++ {
-			pvcStatus, err = r.pvcStatus(pvc)
-			if err != nil && !errors.IsNotFound(err) {
-				return reconcile.Result{}, fmt.Errorf("get pvc status: %v", err)
-			}
-
-			if pvcStatus == VolumeBound {
-				break
-			}
-			time.Sleep(time.Second * i)
+		if err := bcp.SetStorageS3(&job.Spec, bcpStorage.S3, destination); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage S3: %v", err)
 		}
 
-		if pvcStatus != VolumeBound {
-			return reconcile.Result{}, fmt.Errorf("pvc not ready, status: %s", pvcStatus)
+		s3status = &bcpStorage.S3
+	case api.BackupStorageGCS:
+		destination = "gs://" + bcpStorage.GCS.Bucket + "/" + storage.BackupObjectName(instance)
+		if err := bcp.SetStorageGCS(&job.Spec, bcpStorage.GCS, destination); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage GCS: %v", err)
 		}
 
-		err := bcp.SetStoragePVC(&job.Spec, pvc.Name)
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("set storage FS: %v", err)
-		}
-	case api.BackupStorageS3:
-		destination = bcpStorage.S3.Bucket + "/" + instance.Spec.PXCCluster + "-" + instance.CreationTimestamp.Time.Format("2006-02-01-15:04:05") + "-xtrabackup.stream"
-		if !strings.HasPrefix(bcpStorage.S3.Bucket, "s3://") {
-			destination = "s3://" + destination
-		}
-		err := bcp.SetStorageS3(&job.Spec, bcpStorage.S3, destination)
-		if err != nil {
-			return reconcile.Result{}, fmt.Errorf("set storage FS: %v", err)
+		gcsStatus = &bcpStorage.GCS
+	case api.BackupStorageAzure:
+		destination = "azure://" + bcpStorage.Azure.Container + "/" + storage.BackupObjectName(instance)
+		if err := bcp.SetStorageAzure(&job.Spec, bcpStorage.Azure, destination); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage Azure: %v", err)
 		}
 
-		s3status = &bcpStorage.S3
+		azureStatus = &bcpStorage.Azure
+	}
+
+	if err := bcp.SetEncryption(&job.Spec, instance.Spec.Encryption); err != nil {
+		return reconcile.Result{}, fmt.Errorf("set encryption: %v", err)
 	}
 
-	// Set PerconaXtraDBBackup instance as the owner and controller
 	if err := setControllerReference(instance, job, r.scheme); err != nil {
 		return reconcile.Result{}, fmt.Errorf("job/setControllerReference: %v", err)
 	}
@@ -205,9 +254,72 @@ func (r *ReconcilePerconaXtraDBBackup) Reconcile(request reconcile.Request) (rec
 		reqLogger.Info("Created a new backup job", "Namespace", job.Namespace, "Name", job.Name)
 	}
 
-	err = r.updateJobStatus(instance, job, destination, instance.Spec.StorageName, s3status)
+	instance.Status.Destination = destination
+	instance.Status.StorageName = instance.Spec.StorageName
+	instance.Status.S3 = s3status
+	instance.Status.GCS = gcsStatus
+	instance.Status.Azure = azureStatus
+	instance.Status.Encryption = instance.Spec.Encryption
+
+	return reconcile.Result{RequeueAfter: requeueFast}, r.transitionPhase(instance, api.BackupPhaseRunning, "JobCreated", "backup job "+job.Name+" created")
+}
+
+// reconcileRunning polls the backup Job's status and mirrors it onto
+// instance.Status, moving to a terminal phase once the Job finishes.
+func (r *ReconcilePerconaXtraDBBackup) reconcileRunning(reqLogger logr.Logger, instance *api.PerconaXtraDBBackup) (reconcile.Result, error) {
+	cluster, err := r.getClusterConfig(instance)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("invalid backup cluster: %v", err)
+	}
+
+	bcp := backup.New(cluster, cluster.Spec.Backup)
+	job := bcp.Job(instance)
+
+	err = r.client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, job)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{RequeueAfter: requeueFast}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("get backup job: %v", err)
+	}
+
+	switch {
+	case job.Status.Succeeded == 1:
+		instance.Status.State = api.BackupSucceeded
+		instance.Status.CompletedAt = job.Status.CompletionTime
+		return reconcile.Result{}, r.transitionPhase(instance, api.BackupPhaseSucceeded, "JobSucceeded", "backup job "+job.Name+" succeeded")
+	case job.Status.Failed == 1:
+		instance.Status.State = api.BackupFailed
+		return reconcile.Result{}, r.fail(instance, "JobFailed", "backup job "+job.Name+" failed")
+	case job.Status.Active == 1:
+		instance.Status.State = api.BackupRunning
+		if err := r.client.Update(context.TODO(), instance); err != nil {
+			return reconcile.Result{}, fmt.Errorf("update backup status: %v", err)
+		}
+		return reconcile.Result{RequeueAfter: requeueSlow}, nil
+	}
 
-	return rr, err
+	return reconcile.Result{RequeueAfter: requeueFast}, nil
+}
+
+// validate resolves and sanity-checks the target cluster and storage for
+// instance, without mutating anything. It is safe to call from any phase.
+func (r *ReconcilePerconaXtraDBBackup) validate(instance *api.PerconaXtraDBBackup) (*api.PerconaXtraDBCluster, api.BackupStorageSpec, error) {
+	cluster, err := r.getClusterConfig(instance)
+	if err != nil {
+		return nil, api.BackupStorageSpec{}, fmt.Errorf("invalid backup cluster: %v", err)
+	}
+
+	if cluster.Spec.Backup == nil {
+		return nil, api.BackupStorageSpec{}, fmt.Errorf("a backup image should be set in the PXC config")
+	}
+
+	bcpStorage, ok := cluster.Spec.Backup.Storages[instance.Spec.StorageName]
+	if !ok {
+		return nil, api.BackupStorageSpec{}, fmt.Errorf("bcpStorage %s doesn't exist", instance.Spec.StorageName)
+	}
+
+	return cluster, bcpStorage, nil
 }
 
 func (r *ReconcilePerconaXtraDBBackup) getClusterConfig(cr *api.PerconaXtraDBBackup) (*api.PerconaXtraDBCluster, error) {
@@ -234,60 +346,53 @@ func (r *ReconcilePerconaXtraDBBackup) getClusterConfig(cr *api.PerconaXtraDBBac
 	return nil, fmt.Errorf("wrong cluster name: %q. Clusters avaliable: %q", cr.Spec.PXCCluster, availableClusters)
 }
 
-// VolumeStatus describe the status backup PVC
-type VolumeStatus string
+// transitionPhase advances instance to phase, records a Condition for the
+// transition, and persists the status update.
+func (r *ReconcilePerconaXtraDBBackup) transitionPhase(instance *api.PerconaXtraDBBackup, phase api.BackupPhase, reason, message string) error {
+	instance.Status.Phase = phase
+	setCondition(instance, phaseConditionType(phase), metav1.ConditionTrue, reason, message)
 
-const (
-	VolumeUndefined VolumeStatus = "Undefined"
-	VolumeBound                  = VolumeStatus(corev1.ClaimBound)
-	VolumePending                = VolumeStatus(corev1.ClaimPending)
-	VolumeLost                   = VolumeStatus(corev1.ClaimLost)
-)
-
-func (r *ReconcilePerconaXtraDBBackup) pvcStatus(pvc *corev1.PersistentVolumeClaim) (VolumeStatus, error) {
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, pvc)
-	if err != nil {
-		return VolumeUndefined, err
-	}
-
-	return VolumeStatus(pvc.Status.Phase), nil
+	return r.client.Update(context.TODO(), instance)
 }
 
-func (r *ReconcilePerconaXtraDBBackup) updateJobStatus(bcp *api.PerconaXtraDBBackup, job *batchv1.Job, destination, storageName string, s3 *api.BackupStorageS3Spec) error {
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, job)
-
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil
-		}
+// fail moves instance to the terminal Failed phase and records why.
+func (r *ReconcilePerconaXtraDBBackup) fail(instance *api.PerconaXtraDBBackup, reason, message string) error {
+	instance.Status.State = api.BackupFailed
+	return r.transitionPhase(instance, api.BackupPhaseFailed, reason, message)
+}
 
-		return fmt.Errorf("get backup status: %v", err)
-	}
+// setCondition records a non-terminal condition (e.g. still waiting on a
+// PVC) without changing instance.Status.Phase, and persists it.
+func (r *ReconcilePerconaXtraDBBackup) setCondition(instance *api.PerconaXtraDBBackup, condType string, status metav1.ConditionStatus, reason, message string) error {
+	setCondition(instance, condType, status, reason, message)
+	return r.client.Update(context.TODO(), instance)
+}
 
-	status := api.PXCBackupStatus{
-		State:       api.BackupStarting,
-		Destination: destination,
-		StorageName: storageName,
-		S3:          s3,
+func setCondition(instance *api.PerconaXtraDBBackup, condType string, status metav1.ConditionStatus, reason, message string) {
+	now := metav1.Now()
+	cond := metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
 	}
 
-	switch {
-	case job.Status.Active == 1:
-		status.State = api.BackupRunning
-	case job.Status.Succeeded == 1:
-		status.State = api.BackupSucceeded
-		status.CompletedAt = job.Status.CompletionTime
-	case job.Status.Failed == 1:
-		status.State = api.BackupFailed
+	for i, existing := range instance.Status.Conditions {
+		if existing.Type == condType {
+			if existing.Status == status && existing.Reason == reason && existing.Message == message {
+				return
+			}
+			instance.Status.Conditions[i] = cond
+			return
+		}
 	}
 
-	// don't update the status if there aren't any changes.
-	if reflect.DeepEqual(bcp.Status, status) {
-		return nil
-	}
+	instance.Status.Conditions = append(instance.Status.Conditions, cond)
+}
 
-	bcp.Status = status
-	return r.client.Update(context.TODO(), bcp)
+func phaseConditionType(phase api.BackupPhase) string {
+	return "Phase" + string(phase)
 }
 
 func setControllerReference(cr *api.PerconaXtraDBBackup, obj metav1.Object, scheme *runtime.Scheme) error {
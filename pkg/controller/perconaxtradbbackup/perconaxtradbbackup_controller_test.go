@@ -0,0 +1,65 @@
+package perconaxtradbbackup
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+func TestSetConditionAppendsNewType(t *testing.T) {
+	instance := &api.PerconaXtraDBBackup{}
+
+	setCondition(instance, "PVCUnavailable", metav1.ConditionFalse, "PVCPending", "pvc is still pending")
+
+	if len(instance.Status.Conditions) != 1 {
+		t.Fatalf("Conditions = %v, want 1 entry", instance.Status.Conditions)
+	}
+	cond := instance.Status.Conditions[0]
+	if cond.Type != "PVCUnavailable" || cond.Status != metav1.ConditionFalse || cond.Reason != "PVCPending" {
+		t.Errorf("unexpected condition: %+v", cond)
+	}
+}
+
+func TestSetConditionIdenticalUpdateIsNoop(t *testing.T) {
+	instance := &api.PerconaXtraDBBackup{}
+
+	setCondition(instance, "PVCUnavailable", metav1.ConditionFalse, "PVCPending", "pvc is still pending")
+	first := instance.Status.Conditions[0].LastTransitionTime
+
+	setCondition(instance, "PVCUnavailable", metav1.ConditionFalse, "PVCPending", "pvc is still pending")
+
+	if len(instance.Status.Conditions) != 1 {
+		t.Fatalf("Conditions = %v, want still 1 entry after identical update", instance.Status.Conditions)
+	}
+	if instance.Status.Conditions[0].LastTransitionTime != first {
+		t.Errorf("LastTransitionTime changed on a no-op update")
+	}
+}
+
+func TestSetConditionSameTypeDifferentStatusReplaces(t *testing.T) {
+	instance := &api.PerconaXtraDBBackup{}
+
+	setCondition(instance, "PVCUnavailable", metav1.ConditionFalse, "PVCPending", "pvc is still pending")
+	setCondition(instance, "PVCUnavailable", metav1.ConditionTrue, "PVCUnavailable", "pvc is Lost")
+
+	if len(instance.Status.Conditions) != 1 {
+		t.Fatalf("Conditions = %v, want the existing entry replaced in place, not appended", instance.Status.Conditions)
+	}
+	cond := instance.Status.Conditions[0]
+	if cond.Status != metav1.ConditionTrue || cond.Reason != "PVCUnavailable" || cond.Message != "pvc is Lost" {
+		t.Errorf("unexpected condition after replace: %+v", cond)
+	}
+}
+
+func TestSetConditionDifferentTypeAppends(t *testing.T) {
+	instance := &api.PerconaXtraDBBackup{}
+
+	setCondition(instance, "PhaseNew", metav1.ConditionTrue, "Created", "backup created")
+	setCondition(instance, "PVCUnavailable", metav1.ConditionFalse, "PVCPending", "pvc is still pending")
+
+	if len(instance.Status.Conditions) != 2 {
+		t.Fatalf("Conditions = %v, want 2 distinct entries", instance.Status.Conditions)
+	}
+}
@@ -0,0 +1,384 @@
+package perconaxtradbbackupschedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+)
+
+var log = logf.Log.WithName("controller_perconaxtradbbackupschedule")
+
+// scheduleLabel marks the PerconaXtraDBBackup objects a
+// PerconaXtraDBBackupSchedule has created, so they can be listed back for
+// retention without needing an index on owner references.
+const scheduleLabel = "pxc-backup-schedule"
+
+// Add creates a new PerconaXtraDBBackupSchedule Controller and adds it to the Manager. The Manager will set fields on
+// the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	r := newReconciler(mgr)
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	c := cron.New()
+	c.Start()
+
+	return &ReconcilePerconaXtraDBBackupSchedule{
+		client:  mgr.GetClient(),
+		scheme:  mgr.GetScheme(),
+		cron:    c,
+		entries: make(map[types.NamespacedName]scheduleEntry),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("perconaxtradbbackupschedule-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource PerconaXtraDBBackupSchedule
+	err = c.Watch(&source.Kind{Type: &api.PerconaXtraDBBackupSchedule{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBBackupSchedule{}
+
+// scheduleEntry tracks the live cron registration for one
+// PerconaXtraDBBackupSchedule, so Reconcile can tell whether it needs to be
+// re-synced after a spec change.
+type scheduleEntry struct {
+	id     cron.EntryID
+	cronFn string
+}
+
+// ReconcilePerconaXtraDBBackupSchedule reconciles a PerconaXtraDBBackupSchedule object
+type ReconcilePerconaXtraDBBackupSchedule struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+
+	// cron is a single, long-lived runner shared by every
+	// PerconaXtraDBBackupSchedule in the cluster; entries are re-synced
+	// against it on every Reconcile rather than rebuilt from scratch.
+	cron    *cron.Cron
+	mu      sync.Mutex
+	entries map[types.NamespacedName]scheduleEntry
+}
+
+// Reconcile re-syncs the cron entry for a PerconaXtraDBBackupSchedule and
+// prunes its child PerconaXtraDBBackup objects down to the configured
+// retention window.
+//
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcilePerconaXtraDBBackupSchedule) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	instance := &api.PerconaXtraDBBackupSchedule{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.removeEntry(request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if err := r.syncEntry(reqLogger, request.NamespacedName, instance.Spec.Schedule); err != nil {
+		return reconcile.Result{}, fmt.Errorf("sync cron entry: %v", err)
+	}
+
+	if err := r.pruneAndUpdateStatus(instance); err != nil {
+		return reconcile.Result{}, fmt.Errorf("prune backups: %v", err)
+	}
+
+	return reconcile.Result{RequeueAfter: time.Minute}, nil
+}
+
+// syncEntry makes sure the cron runner has exactly one up-to-date entry for
+// namespacedName, replacing it if the schedule string changed.
+func (r *ReconcilePerconaXtraDBBackupSchedule) syncEntry(reqLogger logr.Logger, namespacedName types.NamespacedName, schedule string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.entries[namespacedName]; ok {
+		if existing.cronFn == schedule {
+			return nil
+		}
+		r.cron.Remove(existing.id)
+		delete(r.entries, namespacedName)
+	}
+
+	id, err := r.cron.AddFunc(schedule, func() {
+		r.tick(namespacedName)
+	})
+	if err != nil {
+		return fmt.Errorf("add cron entry %q: %v", schedule, err)
+	}
+
+	reqLogger.Info("Registered backup schedule", "Schedule", schedule)
+	r.entries[namespacedName] = scheduleEntry{id: id, cronFn: schedule}
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBBackupSchedule) removeEntry(namespacedName types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[namespacedName]
+	if !ok {
+		return
+	}
+
+	r.cron.Remove(entry.id)
+	delete(r.entries, namespacedName)
+}
+
+// tick is invoked by the cron runner and creates a new, timestamped
+// PerconaXtraDBBackup for the schedule. It re-fetches the schedule so it
+// always acts on the latest spec, even though the cron entry was registered
+// some time ago.
+func (r *ReconcilePerconaXtraDBBackupSchedule) tick(namespacedName types.NamespacedName) {
+	reqLogger := log.WithValues("Request.Namespace", namespacedName.Namespace, "Request.Name", namespacedName.Name)
+
+	instance := &api.PerconaXtraDBBackupSchedule{}
+	if err := r.client.Get(context.TODO(), namespacedName, instance); err != nil {
+		reqLogger.Error(err, "get backup schedule")
+		return
+	}
+
+	now := time.Now()
+	bcp := &api.PerconaXtraDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", instance.Name, now.Format("20060102150405")),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				scheduleLabel: instance.Name,
+			},
+		},
+		Spec: api.PXCBackupSpec{
+			PXCCluster:  instance.Spec.PXCCluster,
+			StorageName: instance.Spec.StorageName,
+		},
+	}
+
+	if err := setControllerReference(instance, bcp, r.scheme); err != nil {
+		reqLogger.Error(err, "setControllerReference")
+		return
+	}
+
+	if err := r.client.Create(context.TODO(), bcp); err != nil && !errors.IsAlreadyExists(err) {
+		reqLogger.Error(err, "create scheduled backup", "Name", bcp.Name)
+		return
+	}
+
+	instance.Status.LastScheduleTime = &metav1.Time{Time: now}
+	if err := r.client.Update(context.TODO(), instance); err != nil {
+		reqLogger.Error(err, "update backup schedule status")
+	}
+}
+
+// pruneAndUpdateStatus lists the backups this schedule owns, deletes the
+// ones outside the retention window, and refreshes Status with what's left.
+func (r *ReconcilePerconaXtraDBBackupSchedule) pruneAndUpdateStatus(instance *api.PerconaXtraDBBackupSchedule) error {
+	children := api.PerconaXtraDBBackupList{}
+	err := r.client.List(context.TODO(),
+		&client.ListOptions{
+			Namespace:     instance.Namespace,
+			LabelSelector: labels.SelectorFromSet(map[string]string{scheduleLabel: instance.Name}),
+		},
+		&children,
+	)
+	if err != nil {
+		return fmt.Errorf("list backups: %v", err)
+	}
+
+	succeeded := make([]api.PerconaXtraDBBackup, 0, len(children.Items))
+	for _, bcp := range children.Items {
+		if bcp.Status.State == api.BackupSucceeded {
+			succeeded = append(succeeded, bcp)
+		}
+	}
+
+	sort.Slice(succeeded, func(i, j int) bool {
+		return succeeded[i].CreationTimestamp.After(succeeded[j].CreationTimestamp.Time)
+	})
+
+	keep := retain(succeeded, instance.Spec)
+	keepNames := make(map[string]bool, len(keep))
+	active := make([]string, 0, len(keep))
+	for _, bcp := range keep {
+		keepNames[bcp.Name] = true
+		active = append(active, bcp.Name)
+	}
+
+	for i := range succeeded {
+		bcp := succeeded[i]
+		if keepNames[bcp.Name] {
+			continue
+		}
+		if err := r.deleteBackup(&bcp); err != nil {
+			return fmt.Errorf("delete backup %s: %v", bcp.Name, err)
+		}
+	}
+
+	if len(keep) > 0 {
+		instance.Status.LastSuccessfulTime = keep[0].Status.CompletedAt
+	}
+	instance.Status.ActiveBackups = active
+
+	return r.client.Update(context.TODO(), instance)
+}
+
+// retain applies KeepLast on top of GFS daily/weekly/monthly counters and
+// returns the subset of backups (newest first) that should survive pruning.
+// With no retention field set, there's nothing to prune by, so everything is
+// kept rather than treating "no policy configured" as "delete everything".
+func retain(succeeded []api.PerconaXtraDBBackup, spec api.PXCBackupScheduleSpec) []api.PerconaXtraDBBackup {
+	if spec.KeepLast <= 0 && spec.KeepDaily == nil && spec.KeepWeekly == nil && spec.KeepMonthly == nil {
+		kept := make([]api.PerconaXtraDBBackup, len(succeeded))
+		copy(kept, succeeded)
+		return kept
+	}
+
+	keep := make(map[string]bool)
+
+	for i, bcp := range succeeded {
+		if i < spec.KeepLast {
+			keep[bcp.Name] = true
+		}
+	}
+
+	keepByBucket(succeeded, spec.KeepDaily, dailyBucket, keep)
+	keepByBucket(succeeded, spec.KeepWeekly, weeklyBucket, keep)
+	keepByBucket(succeeded, spec.KeepMonthly, monthlyBucket, keep)
+
+	kept := make([]api.PerconaXtraDBBackup, 0, len(keep))
+	for _, bcp := range succeeded {
+		if keep[bcp.Name] {
+			kept = append(kept, bcp)
+		}
+	}
+
+	return kept
+}
+
+func dailyBucket(t time.Time) string   { return t.Format("2006-01-02") }
+func monthlyBucket(t time.Time) string { return t.Format("2006-01") }
+func weeklyBucket(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// keepByBucket keeps the newest backup in each distinct time bucket
+// (day/week/month, depending on bucketOf), up to limit buckets.
+func keepByBucket(succeeded []api.PerconaXtraDBBackup, limit *int, bucketOf func(time.Time) string, keep map[string]bool) {
+	if limit == nil || *limit <= 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, bcp := range succeeded {
+		bucket := bucketOf(bcp.CreationTimestamp.Time)
+		if seen[bucket] {
+			continue
+		}
+		if len(seen) >= *limit {
+			break
+		}
+		seen[bucket] = true
+		keep[bcp.Name] = true
+	}
+}
+
+// deleteBackup removes the artifact backing bcp (PVC or S3 object) before
+// deleting the CR itself, so retention actually frees storage rather than
+// just hiding it from kubectl.
+func (r *ReconcilePerconaXtraDBBackupSchedule) deleteBackup(bcp *api.PerconaXtraDBBackup) error {
+	switch {
+	case bcp.Status.S3 != nil:
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Name: bcp.Status.S3.CredentialsSecret, Namespace: bcp.Namespace}
+		if err := r.client.Get(context.TODO(), key, secret); err != nil {
+			return fmt.Errorf("get s3 credentials secret: %v", err)
+		}
+		if err := backup.DeleteObject(*bcp.Status.S3, secret, bcp.Status.Destination); err != nil {
+			return fmt.Errorf("delete s3 object: %v", err)
+		}
+	case bcp.Status.GCS != nil:
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Name: bcp.Status.GCS.CredentialsSecret, Namespace: bcp.Namespace}
+		if err := r.client.Get(context.TODO(), key, secret); err != nil {
+			return fmt.Errorf("get gcs credentials secret: %v", err)
+		}
+		if err := backup.DeleteGCSObject(*bcp.Status.GCS, secret, bcp.Status.Destination); err != nil {
+			return fmt.Errorf("delete gcs object: %v", err)
+		}
+	case bcp.Status.Azure != nil:
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Name: bcp.Status.Azure.CredentialsSecret, Namespace: bcp.Namespace}
+		if err := r.client.Get(context.TODO(), key, secret); err != nil {
+			return fmt.Errorf("get azure credentials secret: %v", err)
+		}
+		if err := backup.DeleteAzureObject(*bcp.Status.Azure, secret, bcp.Status.Destination); err != nil {
+			return fmt.Errorf("delete azure object: %v", err)
+		}
+	case strings.HasPrefix(bcp.Status.Destination, "pvc/"):
+		pvcName := strings.TrimPrefix(bcp.Status.Destination, "pvc/")
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: bcp.Namespace},
+		}
+		if err := r.client.Delete(context.TODO(), pvc); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("delete backup pvc: %v", err)
+		}
+	}
+
+	if err := r.client.Delete(context.TODO(), bcp); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func setControllerReference(cr *api.PerconaXtraDBBackupSchedule, obj metav1.Object, scheme *runtime.Scheme) error {
+	ownerRef, err := cr.OwnerRef(scheme)
+	if err != nil {
+		return err
+	}
+	obj.SetOwnerReferences(append(obj.GetOwnerReferences(), ownerRef))
+	return nil
+}
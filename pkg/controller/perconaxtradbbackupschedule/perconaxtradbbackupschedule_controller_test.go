@@ -0,0 +1,89 @@
+package perconaxtradbbackupschedule
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+func backupAt(name string, t time.Time) api.PerconaXtraDBBackup {
+	return api.PerconaXtraDBBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(t),
+		},
+	}
+}
+
+func intPtr(i int) *int { return &i }
+
+func TestRetainKeepLast(t *testing.T) {
+	now := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+	succeeded := []api.PerconaXtraDBBackup{
+		backupAt("b0", now),
+		backupAt("b1", now.Add(-24*time.Hour)),
+		backupAt("b2", now.Add(-48*time.Hour)),
+	}
+
+	kept := retain(succeeded, api.PXCBackupScheduleSpec{KeepLast: 2})
+	if len(kept) != 2 || kept[0].Name != "b0" || kept[1].Name != "b1" {
+		t.Fatalf("retain() = %v, want [b0 b1]", names(kept))
+	}
+}
+
+func TestRetainKeepDaily(t *testing.T) {
+	now := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+	succeeded := []api.PerconaXtraDBBackup{
+		backupAt("today-2", now),
+		backupAt("today-1", now.Add(-time.Hour)),
+		backupAt("yesterday", now.Add(-24*time.Hour)),
+		backupAt("two-days-ago", now.Add(-48*time.Hour)),
+	}
+
+	kept := retain(succeeded, api.PXCBackupScheduleSpec{KeepDaily: intPtr(2)})
+
+	want := map[string]bool{"today-2": true, "yesterday": true}
+	if len(kept) != len(want) {
+		t.Fatalf("retain() = %v, want newest-per-day for 2 days: %v", names(kept), want)
+	}
+	for _, bcp := range kept {
+		if !want[bcp.Name] {
+			t.Errorf("retain() kept unexpected backup %s", bcp.Name)
+		}
+	}
+}
+
+func TestRetainNoLimitsKeepsEverything(t *testing.T) {
+	now := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+	succeeded := []api.PerconaXtraDBBackup{
+		backupAt("b0", now),
+		backupAt("b1", now.Add(-24*time.Hour)),
+	}
+
+	kept := retain(succeeded, api.PXCBackupScheduleSpec{})
+	if len(kept) != len(succeeded) {
+		t.Fatalf("retain() = %v, want every backup kept when no retention is configured", names(kept))
+	}
+}
+
+func TestKeepByBucketNilLimitIsNoop(t *testing.T) {
+	now := time.Date(2021, 1, 10, 12, 0, 0, 0, time.UTC)
+	succeeded := []api.PerconaXtraDBBackup{backupAt("b0", now)}
+
+	keep := make(map[string]bool)
+	keepByBucket(succeeded, nil, dailyBucket, keep)
+	if len(keep) != 0 {
+		t.Fatalf("keepByBucket() with nil limit kept %v, want none", keep)
+	}
+}
+
+func names(backups []api.PerconaXtraDBBackup) []string {
+	out := make([]string, len(backups))
+	for i, bcp := range backups {
+		out[i] = bcp.Name
+	}
+	return out
+}
@@ -0,0 +1,350 @@
+package perconaxtradbrestore
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
+)
+
+var log = logf.Log.WithName("controller_perconaxtradbrestore")
+
+// Add creates a new PerconaXtraDBRestore Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	r, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	sv, err := version.Server()
+	if err != nil {
+		return nil, fmt.Errorf("get version: %v", err)
+	}
+
+	return &ReconcilePerconaXtraDBRestore{
+		client:        mgr.GetClient(),
+		scheme:        mgr.GetScheme(),
+		serverVersion: sv,
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("perconaxtradbrestore-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource PerconaXtraDBRestore
+	err = c.Watch(&source.Kind{Type: &api.PerconaXtraDBRestore{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBRestore{}
+
+// ReconcilePerconaXtraDBRestore reconciles a PerconaXtraDBRestore object
+type ReconcilePerconaXtraDBRestore struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+
+	serverVersion *api.ServerVersion
+}
+
+// Reconcile reads that state of the cluster for a PerconaXtraDBRestore object and makes changes based on the state read
+// and what is in the PerconaXtraDBRestore.Spec
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcilePerconaXtraDBRestore) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	rr := reconcile.Result{
+		RequeueAfter: time.Second * 5,
+	}
+
+	// Fetch the PerconaXtraDBRestore instance
+	instance := &api.PerconaXtraDBRestore{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return rr, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// terminal states don't recreate the restore job
+	if instance.Status.State == api.RestoreSucceeded || instance.Status.State == api.RestoreFailed {
+		return reconcile.Result{}, nil
+	}
+
+	cluster, err := r.getClusterConfig(instance)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("invalid restore cluster: %v", err)
+	}
+
+	if cluster.Spec.Backup == nil {
+		return reconcile.Result{}, fmt.Errorf("a backup image should be set in the PXC config")
+	}
+
+	bcpCr, err := r.getBackupConfig(instance)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("invalid backup %s: %v", instance.Spec.BackupName, err)
+	}
+
+	if bcpCr.Status.State != api.BackupSucceeded {
+		return reconcile.Result{}, fmt.Errorf("backup %s isn't succeeded, current state: %s", bcpCr.Name, bcpCr.Status.State)
+	}
+
+	// Block SST/writes on the target cluster for the duration of the
+	// restore by scaling it to 0 before the restore Job touches its data
+	// PVCs, then waiting for its pods to actually be gone.
+	switch instance.Status.State {
+	case "":
+		return r.pauseCluster(reqLogger, instance, cluster)
+	case api.RestorePausingCluster:
+		return r.waitClusterPaused(reqLogger, instance, cluster)
+	}
+
+	bcp := backup.New(cluster, cluster.Spec.Backup)
+	job := bcp.RestoreJob(instance)
+	job.Spec = bcp.RestoreJobSpec(instance)
+
+	switch {
+	case bcpCr.Status.S3 != nil:
+		if err := bcp.SetStorageS3(&job.Spec, *bcpCr.Status.S3, bcpCr.Status.Destination); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage S3: %v", err)
+		}
+	case bcpCr.Status.GCS != nil:
+		if err := bcp.SetStorageGCS(&job.Spec, *bcpCr.Status.GCS, bcpCr.Status.Destination); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage GCS: %v", err)
+		}
+	case bcpCr.Status.Azure != nil:
+		if err := bcp.SetStorageAzure(&job.Spec, *bcpCr.Status.Azure, bcpCr.Status.Destination); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage Azure: %v", err)
+		}
+	default:
+		pvcName := strings.TrimPrefix(bcpCr.Status.Destination, "pvc/")
+		if err := bcp.SetStoragePVC(&job.Spec, pvcName); err != nil {
+			return reconcile.Result{}, fmt.Errorf("set storage FS: %v", err)
+		}
+	}
+
+	if err := bcp.SetEncryption(&job.Spec, bcpCr.Status.Encryption); err != nil {
+		return reconcile.Result{}, fmt.Errorf("set encryption: %v", err)
+	}
+
+	// Set PerconaXtraDBRestore instance as the owner and controller
+	if err := setControllerReference(instance, job, r.scheme); err != nil {
+		return reconcile.Result{}, fmt.Errorf("job/setControllerReference: %v", err)
+	}
+
+	err = r.client.Create(context.TODO(), job)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return reconcile.Result{}, fmt.Errorf("create restore job: %v", err)
+	} else if err == nil {
+		reqLogger.Info("Created a new restore job", "Namespace", job.Namespace, "Name", job.Name)
+	}
+
+	if err := r.updateJobStatus(instance, job); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if instance.Status.State == api.RestoreSucceeded || instance.Status.State == api.RestoreFailed {
+		if err := r.resumeCluster(instance, cluster); err != nil {
+			return reconcile.Result{}, fmt.Errorf("resume pxc statefulset: %v", err)
+		}
+	}
+
+	return rr, nil
+}
+
+// pauseCluster scales the target PXC StatefulSet to 0, remembering its
+// previous replica count so resumeCluster can restore it later, and moves
+// instance into RestorePausingCluster while that scale-down takes effect.
+func (r *ReconcilePerconaXtraDBRestore) pauseCluster(reqLogger logr.Logger, instance *api.PerconaXtraDBRestore, cluster *api.PerconaXtraDBCluster) (reconcile.Result, error) {
+	ss, err := r.getPXCStatefulSet(instance, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	replicas := int32(1)
+	if ss.Spec.Replicas != nil {
+		replicas = *ss.Spec.Replicas
+	}
+
+	zero := int32(0)
+	ss.Spec.Replicas = &zero
+	if err := r.client.Update(context.TODO(), ss); err != nil {
+		return reconcile.Result{}, fmt.Errorf("pause pxc statefulset: %v", err)
+	}
+
+	instance.Status.PausedReplicas = &replicas
+	instance.Status.State = api.RestorePausingCluster
+	if err := r.client.Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{}, fmt.Errorf("update restore status: %v", err)
+	}
+
+	reqLogger.Info("Pausing PXC cluster for restore", "Namespace", ss.Namespace, "Name", ss.Name)
+
+	return reconcile.Result{RequeueAfter: time.Second * 5}, nil
+}
+
+// waitClusterPaused requeues until the PXC StatefulSet has actually scaled
+// down to 0 pods, then advances instance to RestoreStarting.
+func (r *ReconcilePerconaXtraDBRestore) waitClusterPaused(reqLogger logr.Logger, instance *api.PerconaXtraDBRestore, cluster *api.PerconaXtraDBCluster) (reconcile.Result, error) {
+	ss, err := r.getPXCStatefulSet(instance, cluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if ss.Status.Replicas > 0 {
+		return reconcile.Result{RequeueAfter: time.Second * 5}, nil
+	}
+
+	instance.Status.State = api.RestoreStarting
+	if err := r.client.Update(context.TODO(), instance); err != nil {
+		return reconcile.Result{}, fmt.Errorf("update restore status: %v", err)
+	}
+
+	return reconcile.Result{RequeueAfter: time.Second * 5}, nil
+}
+
+// resumeCluster scales the PXC StatefulSet back to the replica count
+// pauseCluster recorded, once the restore Job has reached a terminal state.
+func (r *ReconcilePerconaXtraDBRestore) resumeCluster(instance *api.PerconaXtraDBRestore, cluster *api.PerconaXtraDBCluster) error {
+	if instance.Status.PausedReplicas == nil {
+		return nil
+	}
+
+	ss, err := r.getPXCStatefulSet(instance, cluster)
+	if err != nil {
+		return err
+	}
+
+	ss.Spec.Replicas = instance.Status.PausedReplicas
+	return r.client.Update(context.TODO(), ss)
+}
+
+func (r *ReconcilePerconaXtraDBRestore) getPXCStatefulSet(instance *api.PerconaXtraDBRestore, cluster *api.PerconaXtraDBCluster) (*appsv1.StatefulSet, error) {
+	ss := &appsv1.StatefulSet{}
+	name := types.NamespacedName{Name: cluster.Name + "-pxc", Namespace: instance.Namespace}
+	if err := r.client.Get(context.TODO(), name, ss); err != nil {
+		return nil, fmt.Errorf("get pxc statefulset: %v", err)
+	}
+
+	return ss, nil
+}
+
+func (r *ReconcilePerconaXtraDBRestore) getClusterConfig(cr *api.PerconaXtraDBRestore) (*api.PerconaXtraDBCluster, error) {
+	clusterList := api.PerconaXtraDBClusterList{}
+	err := r.client.List(context.TODO(),
+		&client.ListOptions{
+			Namespace: cr.Namespace,
+		},
+		&clusterList,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("get clusters list: %v", err)
+	}
+
+	availableClusters := make([]string, 0)
+	for _, cluster := range clusterList.Items {
+		if cluster.Name == cr.Spec.PXCCluster {
+			return &cluster, nil
+		}
+		availableClusters = append(availableClusters, cluster.Name)
+	}
+
+	return nil, fmt.Errorf("wrong cluster name: %q. Clusters avaliable: %q", cr.Spec.PXCCluster, availableClusters)
+}
+
+func (r *ReconcilePerconaXtraDBRestore) getBackupConfig(cr *api.PerconaXtraDBRestore) (*api.PerconaXtraDBBackup, error) {
+	bcp := &api.PerconaXtraDBBackup{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: cr.Spec.BackupName, Namespace: cr.Namespace}, bcp)
+	if err != nil {
+		return nil, fmt.Errorf("get backup: %v", err)
+	}
+
+	return bcp, nil
+}
+
+func (r *ReconcilePerconaXtraDBRestore) updateJobStatus(cr *api.PerconaXtraDBRestore, job *batchv1.Job) error {
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, job)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("get restore status: %v", err)
+	}
+
+	status := api.PerconaXtraDBRestoreStatus{
+		State:          api.RestoreStarting,
+		PausedReplicas: cr.Status.PausedReplicas,
+	}
+
+	switch {
+	case job.Status.Active == 1:
+		status.State = api.RestoreRunning
+	case job.Status.Succeeded == 1:
+		status.State = api.RestoreSucceeded
+		status.CompletedAt = job.Status.CompletionTime
+	case job.Status.Failed == 1:
+		status.State = api.RestoreFailed
+	}
+
+	// don't update the status if there aren't any changes.
+	if reflect.DeepEqual(cr.Status, status) {
+		return nil
+	}
+
+	cr.Status = status
+	return r.client.Update(context.TODO(), cr)
+}
+
+func setControllerReference(cr *api.PerconaXtraDBRestore, obj metav1.Object, scheme *runtime.Scheme) error {
+	ownerRef, err := cr.OwnerRef(scheme)
+	if err != nil {
+		return err
+	}
+	obj.SetOwnerReferences(append(obj.GetOwnerReferences(), ownerRef))
+	return nil
+}
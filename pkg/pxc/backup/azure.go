@@ -0,0 +1,61 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// DeleteAzureObject removes a single blob (identified by its
+// "azure://container/key" destination, as produced by Reconcile) from the
+// given Azure Blob Storage container, using the same account credentials
+// the backup Job was set up with.
+func DeleteAzureObject(spec api.BackupStorageAzureSpec, secret *corev1.Secret, destination string) error {
+	container, key, err := parseAzureDestination(destination)
+	if err != nil {
+		return err
+	}
+
+	accountName := string(secret.Data["AZURE_STORAGE_ACCOUNT_NAME"])
+	accountKey := string(secret.Data["AZURE_STORAGE_ACCOUNT_KEY"])
+
+	credential, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return fmt.Errorf("new azure credential: %v", err)
+	}
+
+	endpoint := spec.EndpointURL
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", accountName)
+	}
+
+	containerURL, err := url.Parse(endpoint + "/" + container)
+	if err != nil {
+		return fmt.Errorf("parse azure container url: %v", err)
+	}
+
+	blobURL := azblob.NewContainerURL(*containerURL, azblob.NewPipeline(credential, azblob.PipelineOptions{})).NewBlobURL(key)
+
+	if _, err := blobURL.Delete(context.Background(), azblob.DeleteSnapshotsOptionNone, azblob.BlobAccessConditions{}); err != nil {
+		return fmt.Errorf("delete azure blob %s/%s: %v", container, key, err)
+	}
+
+	return nil
+}
+
+func parseAzureDestination(destination string) (container, key string, err error) {
+	destination = strings.TrimPrefix(destination, "azure://")
+
+	parts := strings.SplitN(destination, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("malformed azure destination: %q", destination)
+	}
+
+	return parts[0], parts[1], nil
+}
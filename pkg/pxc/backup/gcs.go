@@ -0,0 +1,47 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	corev1 "k8s.io/api/core/v1"
+	"google.golang.org/api/option"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// DeleteGCSObject removes a single object (identified by its "gs://bucket/key"
+// destination, as produced by Reconcile) from the given GCS storage, using
+// the service-account credentials the backup Job was set up with.
+func DeleteGCSObject(spec api.BackupStorageGCSSpec, secret *corev1.Secret, destination string) error {
+	bucket, key, err := parseGCSDestination(destination)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(secret.Data["credentials.json"]))
+	if err != nil {
+		return fmt.Errorf("new gcs client: %v", err)
+	}
+	defer client.Close()
+
+	if err := client.Bucket(bucket).Object(key).Delete(ctx); err != nil {
+		return fmt.Errorf("delete gcs object %s/%s: %v", bucket, key, err)
+	}
+
+	return nil
+}
+
+func parseGCSDestination(destination string) (bucket, key string, err error) {
+	destination = strings.TrimPrefix(destination, "gs://")
+
+	parts := strings.SplitN(destination, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("malformed gcs destination: %q", destination)
+	}
+
+	return parts[0], parts[1], nil
+}
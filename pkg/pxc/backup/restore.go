@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// RestoreJob returns a Job that restores data from the PerconaXtraDBBackup
+// named by cr.Spec.BackupName into the PXC cluster targeted by cr. The caller
+// is expected to point the Job at the backup's storage (PVC, S3, GCS or
+// Azure) via SetStoragePVC/SetStorageS3/SetStorageGCS/SetStorageAzure before
+// creating it, the same way Job/JobSpec are used for the backup side.
+func (b *Backup) RestoreJob(cr *api.PerconaXtraDBRestore) *batchv1.Job {
+	labels := map[string]string{
+		"cluster": b.cluster.Name,
+		"type":    "xtrabackup-restore",
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "restore-job-" + cr.Name,
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+	}
+}
+
+// RestoreJobSpec builds the JobSpec for a restore Job. It runs the same
+// xtrabackup image used for backups, but in restore mode, with the backup
+// destination wired in (by the caller, via the SetStorage* methods) plus
+// one "datadir-<i>" volume per PXC replica so recovery-pxc-restore.sh can
+// write the restored data directly into each node's data PVC. The caller is
+// expected to have already paused the target cluster (scaled its
+// StatefulSet to 0) so nothing is writing to those PVCs while this runs.
+func (b *Backup) RestoreJobSpec(cr *api.PerconaXtraDBRestore) batchv1.JobSpec {
+	backOffLimit := int32(4)
+
+	size := b.cluster.Spec.PXC.Size
+	volumes := make([]corev1.Volume, 0, size)
+	volumeMounts := make([]corev1.VolumeMount, 0, size)
+	for i := int32(0); i < size; i++ {
+		volumeName := fmt.Sprintf("datadir-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: fmt.Sprintf("datadir-%s-pxc-%d", b.cluster.Name, i),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: fmt.Sprintf("/datadir/%d", i),
+		})
+	}
+
+	return batchv1.JobSpec{
+		BackoffLimit: &backOffLimit,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"cluster": b.cluster.Name,
+					"type":    "xtrabackup-restore",
+				},
+			},
+			Spec: corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Volumes:       volumes,
+				Containers: []corev1.Container{
+					{
+						Name:  "xtrabackup-restore",
+						Image: b.image,
+						Command: []string{
+							"recovery-pxc-restore.sh",
+						},
+						Env: []corev1.EnvVar{
+							{
+								Name:  "PXC_CLUSTER",
+								Value: cr.Spec.PXCCluster,
+							},
+							{
+								Name:  "PXC_BACKUP",
+								Value: cr.Spec.BackupName,
+							},
+						},
+						VolumeMounts: volumeMounts,
+					},
+				},
+			},
+		},
+	}
+}
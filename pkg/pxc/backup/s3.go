@@ -0,0 +1,106 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// ObjectInfo describes one object returned by ListObjects.
+type ObjectInfo struct {
+	Key          string
+	LastModified time.Time
+}
+
+func newS3Client(spec api.BackupStorageS3Spec, secret *corev1.Secret) (*s3.S3, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:           aws.String(spec.Region),
+		Endpoint:         aws.String(spec.EndpointURL),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials: credentials.NewStaticCredentials(
+			string(secret.Data["AWS_ACCESS_KEY_ID"]),
+			string(secret.Data["AWS_SECRET_ACCESS_KEY"]),
+			"",
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("new s3 session: %v", err)
+	}
+
+	return s3.New(sess), nil
+}
+
+// DeleteObject removes a single object (identified by its "s3://bucket/key"
+// or "bucket/key" destination, as produced by Reconcile) from the given S3
+// storage, using the same credentials secret the backup Job was set up with.
+func DeleteObject(spec api.BackupStorageS3Spec, secret *corev1.Secret, destination string) error {
+	bucket, key, err := parseS3Destination(destination)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newS3Client(spec, secret)
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("delete s3 object %s/%s: %v", bucket, key, err)
+	}
+
+	return nil
+}
+
+// ListObjects lists every object under prefix in spec.Bucket, for use by the
+// backup adoption controller to discover artifacts that outlived the
+// PerconaXtraDBBackup CR that created them.
+func ListObjects(spec api.BackupStorageS3Spec, secret *corev1.Secret, prefix string) ([]ObjectInfo, error) {
+	bucket := strings.TrimPrefix(spec.Bucket, "s3://")
+
+	svc, err := newS3Client(spec, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []ObjectInfo
+	err = svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:          aws.StringValue(obj.Key),
+				LastModified: aws.TimeValue(obj.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list s3 objects in %s/%s: %v", bucket, prefix, err)
+	}
+
+	return objects, nil
+}
+
+func parseS3Destination(destination string) (bucket, key string, err error) {
+	destination = strings.TrimPrefix(destination, "s3://")
+
+	parts := strings.SplitN(destination, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("malformed s3 destination: %q", destination)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// pvcStorageReconciler provisions the PVC backing a BackupStorageFilesystem
+// destination.
+type pvcStorageReconciler struct {
+	client     client.Client
+	scheme     *runtime.Scheme
+	volumeSpec *api.BackupStorageVolumeSpec
+}
+
+// NewPVCStorageReconciler returns a StorageReconciler for a
+// BackupStorageFilesystem destination.
+func NewPVCStorageReconciler(c client.Client, scheme *runtime.Scheme, volumeSpec *api.BackupStorageVolumeSpec) StorageReconciler {
+	return &pvcStorageReconciler{
+		client:     c,
+		scheme:     scheme,
+		volumeSpec: volumeSpec,
+	}
+}
+
+// Ensure creates (if needed) a PVC uniquely named after instance, owned by
+// it so it's garbage collected with the CR, and checks its current bind
+// status once. It never blocks waiting for the PVC to bind: if it isn't
+// Bound yet, it returns a non-terminal PVCPendingError so the caller can
+// requeue and call Ensure again later, the same way any other still-in-
+// progress dependency is handled in this controller.
+func (s *pvcStorageReconciler) Ensure(ctx context.Context, instance *api.PerconaXtraDBBackup) (string, interface{}, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvc.Name = PVCName(instance)
+	pvc.Namespace = instance.Namespace
+
+	err := s.client.Get(ctx, types.NamespacedName{Name: pvc.Name, Namespace: pvc.Namespace}, pvc)
+	switch {
+	case errors.IsNotFound(err):
+		pvc.Spec = *s.volumeSpec.PersistentVolumeClaim
+
+		ownerRef, err := instance.OwnerRef(s.scheme)
+		if err != nil {
+			return "", nil, fmt.Errorf("setControllerReference: %v", err)
+		}
+		pvc.SetOwnerReferences(append(pvc.GetOwnerReferences(), ownerRef))
+
+		if err := s.client.Create(ctx, pvc); err != nil && !errors.IsAlreadyExists(err) {
+			return "", nil, fmt.Errorf("create backup pvc: %v", err)
+		}
+
+		return "", nil, &PVCPendingError{PVCName: pvc.Name, Status: "Creating"}
+	case err != nil:
+		return "", nil, fmt.Errorf("get backup pvc: %v", err)
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return "pvc/" + pvc.Name, pvc.Name, nil
+	case corev1.ClaimLost:
+		return "", nil, &PVCUnavailableError{PVCName: pvc.Name, Status: string(pvc.Status.Phase)}
+	default:
+		return "", nil, &PVCPendingError{PVCName: pvc.Name, Status: string(pvc.Status.Phase)}
+	}
+}
+
+// PVCName derives a name unique to this backup, rather than the fixed
+// "cluster1-xb-cron-pvc" every backup in a namespace used to collide on.
+func PVCName(instance *api.PerconaXtraDBBackup) string {
+	return instance.Name + "-xb-pvc"
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add corev1 to scheme: %v", err)
+	}
+	if err := api.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatalf("add api to scheme: %v", err)
+	}
+	return scheme
+}
+
+func testInstance() *api.PerconaXtraDBBackup {
+	return &api.PerconaXtraDBBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "bcp1", Namespace: "pxc"},
+	}
+}
+
+func TestPVCStorageReconcilerEnsureNotFoundCreatesAndReturnsPending(t *testing.T) {
+	scheme := newTestScheme(t)
+	instance := testInstance()
+	volumeSpec := &api.BackupStorageVolumeSpec{PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{}}
+
+	c := fake.NewFakeClientWithScheme(scheme)
+	r := NewPVCStorageReconciler(c, scheme, volumeSpec)
+
+	destination, _, err := r.Ensure(context.TODO(), instance)
+	if destination != "" {
+		t.Errorf("destination = %q, want empty while pending", destination)
+	}
+
+	var pendingErr *PVCPendingError
+	if !stderrors.As(err, &pendingErr) {
+		t.Fatalf("Ensure() err = %v, want *PVCPendingError", err)
+	}
+	if pendingErr.Status != "Creating" {
+		t.Errorf("pendingErr.Status = %q, want Creating", pendingErr.Status)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	key := types.NamespacedName{Name: PVCName(instance), Namespace: instance.Namespace}
+	if err := c.Get(context.TODO(), key, pvc); err != nil {
+		t.Fatalf("expected pvc to be created, get failed: %v", err)
+	}
+}
+
+func TestPVCStorageReconcilerEnsurePending(t *testing.T) {
+	scheme := newTestScheme(t)
+	instance := testInstance()
+	volumeSpec := &api.BackupStorageVolumeSpec{PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{}}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: PVCName(instance), Namespace: instance.Namespace},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+	}
+
+	c := fake.NewFakeClientWithScheme(scheme, pvc)
+	r := NewPVCStorageReconciler(c, scheme, volumeSpec)
+
+	_, _, err := r.Ensure(context.TODO(), instance)
+
+	var pendingErr *PVCPendingError
+	if !stderrors.As(err, &pendingErr) {
+		t.Fatalf("Ensure() err = %v, want *PVCPendingError", err)
+	}
+	if pendingErr.Status != string(corev1.ClaimPending) {
+		t.Errorf("pendingErr.Status = %q, want %q", pendingErr.Status, corev1.ClaimPending)
+	}
+}
+
+func TestPVCStorageReconcilerEnsureBound(t *testing.T) {
+	scheme := newTestScheme(t)
+	instance := testInstance()
+	volumeSpec := &api.BackupStorageVolumeSpec{PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{}}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: PVCName(instance), Namespace: instance.Namespace},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+
+	c := fake.NewFakeClientWithScheme(scheme, pvc)
+	r := NewPVCStorageReconciler(c, scheme, volumeSpec)
+
+	destination, mountRef, err := r.Ensure(context.TODO(), instance)
+	if err != nil {
+		t.Fatalf("Ensure() err = %v, want nil", err)
+	}
+	if want := "pvc/" + PVCName(instance); destination != want {
+		t.Errorf("destination = %q, want %q", destination, want)
+	}
+	if mountRef != PVCName(instance) {
+		t.Errorf("mountRef = %v, want %q", mountRef, PVCName(instance))
+	}
+}
+
+func TestPVCStorageReconcilerEnsureLost(t *testing.T) {
+	scheme := newTestScheme(t)
+	instance := testInstance()
+	volumeSpec := &api.BackupStorageVolumeSpec{PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{}}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: PVCName(instance), Namespace: instance.Namespace},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimLost},
+	}
+
+	c := fake.NewFakeClientWithScheme(scheme, pvc)
+	r := NewPVCStorageReconciler(c, scheme, volumeSpec)
+
+	_, _, err := r.Ensure(context.TODO(), instance)
+
+	var unavailableErr *PVCUnavailableError
+	if !stderrors.As(err, &unavailableErr) {
+		t.Fatalf("Ensure() err = %v, want *PVCUnavailableError", err)
+	}
+}
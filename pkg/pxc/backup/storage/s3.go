@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// s3StorageReconciler computes the destination for a BackupStorageS3
+// destination. There is nothing to provision up front - xbcloud creates the
+// object as it streams - so Ensure only derives the destination string.
+type s3StorageReconciler struct {
+	spec api.BackupStorageS3Spec
+}
+
+// NewS3StorageReconciler returns a StorageReconciler for a BackupStorageS3
+// destination.
+func NewS3StorageReconciler(spec api.BackupStorageS3Spec) StorageReconciler {
+	return &s3StorageReconciler{spec: spec}
+}
+
+func (s *s3StorageReconciler) Ensure(ctx context.Context, instance *api.PerconaXtraDBBackup) (string, interface{}, error) {
+	destination := s.spec.Bucket + "/" + BackupObjectName(instance)
+	if !strings.HasPrefix(s.spec.Bucket, "s3://") {
+		destination = "s3://" + destination
+	}
+
+	return destination, s.spec, nil
+}
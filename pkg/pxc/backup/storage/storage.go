@@ -0,0 +1,53 @@
+// Package storage provisions the destination a PerconaXtraDBBackup writes
+// to, independent of how the resulting Job is wired up to it. PVC and S3
+// destinations have a StorageReconciler; GCS and Azure destinations have
+// none and are derived directly in the backup controller.
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// StorageReconciler ensures the backend a PerconaXtraDBBackup will write to
+// exists and is ready, and returns the destination string stored in
+// PXCBackupStatus.Destination plus a backend-specific value (e.g. the PVC
+// name, or the S3 spec) the caller needs to point the Job at it.
+type StorageReconciler interface {
+	Ensure(ctx context.Context, instance *api.PerconaXtraDBBackup) (destination string, mountRef interface{}, err error)
+}
+
+// PVCUnavailableError is returned by the PVC StorageReconciler when the
+// backup PVC reaches a state it cannot recover from on its own (Lost), so
+// the caller can surface a PVCUnavailable condition and stop retrying
+// instead of treating it as a transient error.
+type PVCUnavailableError struct {
+	PVCName string
+	Status  string
+}
+
+func (e *PVCUnavailableError) Error() string {
+	return fmt.Sprintf("pvc %s is unavailable, status: %s", e.PVCName, e.Status)
+}
+
+// PVCPendingError is returned by the PVC StorageReconciler while the backup
+// PVC still hasn't bound. It is not terminal: the caller should requeue and
+// call Ensure again later rather than failing the backup, since binding can
+// legitimately take a while (e.g. WaitForFirstConsumer storage classes,
+// provisioner load).
+type PVCPendingError struct {
+	PVCName string
+	Status  string
+}
+
+func (e *PVCPendingError) Error() string {
+	return fmt.Sprintf("pvc %s isn't bound yet, status: %s", e.PVCName, e.Status)
+}
+
+// BackupObjectName is the "<cluster>-<timestamp>-xtrabackup.stream" naming
+// convention shared by every object-storage backend (S3, GCS, Azure).
+func BackupObjectName(instance *api.PerconaXtraDBBackup) string {
+	return instance.Spec.PXCCluster + "-" + instance.CreationTimestamp.Time.Format("2006-02-01-15:04:05") + "-xtrabackup.stream"
+}
@@ -0,0 +1,32 @@
+package backup
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// SetStorageAzure points the backup Job at an Azure Blob Storage
+// destination, injecting the account credentials via envFrom so the
+// xtrabackup image's rclone invocation can authenticate against the
+// container.
+func (b *Backup) SetStorageAzure(jobSpec *batchv1.JobSpec, spec api.BackupStorageAzureSpec, destination string) error {
+	container := &jobSpec.Template.Spec.Containers[0]
+
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "BACKUP_DESTINATION", Value: destination},
+		corev1.EnvVar{Name: "AZURE_CONTAINER_NAME", Value: spec.Container},
+		corev1.EnvVar{Name: "AZURE_ENDPOINT", Value: spec.EndpointURL},
+	)
+
+	container.EnvFrom = append(container.EnvFrom, corev1.EnvFromSource{
+		SecretRef: &corev1.SecretEnvSource{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: spec.CredentialsSecret,
+			},
+		},
+	})
+
+	return nil
+}
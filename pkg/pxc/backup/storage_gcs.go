@@ -0,0 +1,40 @@
+package backup
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1alpha1"
+)
+
+// SetStorageGCS points the backup Job at a Google Cloud Storage destination,
+// mirroring SetStorageS3: it injects the bucket/endpoint as env vars and
+// mounts the service-account credentials so the xtrabackup image's
+// rclone/gsutil invocation can authenticate.
+func (b *Backup) SetStorageGCS(jobSpec *batchv1.JobSpec, spec api.BackupStorageGCSSpec, destination string) error {
+	container := &jobSpec.Template.Spec.Containers[0]
+
+	container.Env = append(container.Env,
+		corev1.EnvVar{Name: "BACKUP_DESTINATION", Value: destination},
+		corev1.EnvVar{Name: "GCS_BUCKET", Value: spec.Bucket},
+		corev1.EnvVar{Name: "GCS_ENDPOINT", Value: spec.EndpointURL},
+		corev1.EnvVar{Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/etc/gcs-credentials/credentials.json"},
+	)
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "gcs-credentials",
+		MountPath: "/etc/gcs-credentials",
+		ReadOnly:  true,
+	})
+
+	jobSpec.Template.Spec.Volumes = append(jobSpec.Template.Spec.Volumes, corev1.Volume{
+		Name: "gcs-credentials",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: spec.CredentialsSecret,
+			},
+		},
+	})
+
+	return nil
+}